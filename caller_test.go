@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestCallerOmittedByDefault checks that RingHandler entries carry no caller
+// info unless some handler in the chain opted in via WithCaller.
+func TestCallerOmittedByDefault(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	l.D("hello")
+
+	if got := ring.Entries()[0].Caller; got != "" {
+		t.Fatalf("expected no caller info by default, got %q", got)
+	}
+}
+
+// TestTextHandlerWithCallerOptsIn checks that setting TextHandler.WithCaller
+// both triggers caller capture and prepends it to the formatted line.
+func TestTextHandlerWithCallerOptsIn(t *testing.T) {
+	var buf bytes.Buffer
+	th := NewTextHandler(&buf)
+	th.WithCaller = true
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", th)
+	l.D("hello")
+
+	if !strings.Contains(buf.String(), "caller_test.go:") {
+		t.Fatalf("expected output to contain a caller reference, got %q", buf.String())
+	}
+}
+
+// TestJSONHandlerOmitsCallerUnlessOptedIn checks that JSONHandler only emits
+// the "caller" field when its own WithCaller is set, even if another handler
+// in the same chain wants caller info computed.
+func TestJSONHandlerOmitsCallerUnlessOptedIn(t *testing.T) {
+	var plainBuf, callerBuf bytes.Buffer
+	plain := NewJSONHandler(&plainBuf)
+	withCaller := NewJSONHandler(&callerBuf)
+	withCaller.WithCaller = true
+
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", plain, withCaller)
+	l.D("hello")
+
+	if strings.Contains(plainBuf.String(), "\"caller\"") {
+		t.Fatalf("expected no caller field without opt-in, got %q", plainBuf.String())
+	}
+	if !strings.Contains(callerBuf.String(), "\"caller\"") {
+		t.Fatalf("expected caller field with opt-in, got %q", callerBuf.String())
+	}
+}
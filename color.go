@@ -0,0 +1,66 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+
+	"golang.org/x/term"
+)
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiCyan    = "\x1b[36m"
+	ansiYellow  = "\x1b[33m"
+	ansiRed     = "\x1b[31m"
+	ansiMagenta = "\x1b[35m"
+)
+
+// levelColor returns the ANSI SGR code used to colorize level's prefix, or
+// "" if the level has no associated color.
+func levelColor(level Level) string {
+	switch level {
+	case LevelDebug:
+		return ansiCyan
+	case LevelWarn:
+		return ansiYellow
+	case LevelError:
+		return ansiRed
+	case LevelFatal:
+		return ansiMagenta
+	default:
+		return ""
+	}
+}
+
+// isColorableTerminal reports whether w is a terminal it's safe to write
+// ANSI escape sequences to: it must be an *os.File backed by a TTY, and the
+// user must not have set NO_COLOR (see https://no-color.org/).
+func isColorableTerminal(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if !term.IsTerminal(int(f.Fd())) {
+		return false
+	}
+	enableWindowsVirtualTerminal(f)
+	return true
+}
+
+// callerInfo returns "file:line" for the stack frame skip levels above
+// callerInfo itself, or "" if it can't be determined. skip follows
+// runtime.Caller's convention: 0 identifies callerInfo, 1 its caller.
+func callerInfo(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return ""
+	}
+	return filepath.Base(file) + ":" + strconv.Itoa(line)
+}
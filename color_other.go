@@ -0,0 +1,9 @@
+//go:build !windows
+
+package logger
+
+import "os"
+
+// enableWindowsVirtualTerminal is a no-op on platforms whose terminals
+// already interpret ANSI escape sequences natively.
+func enableWindowsVirtualTerminal(f *os.File) {}
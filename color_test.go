@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestIsColorableTerminalFalseForNonFile checks that a plain io.Writer (not
+// backed by an *os.File) is never treated as colorable, since there's no fd
+// to run term.IsTerminal on.
+func TestIsColorableTerminalFalseForNonFile(t *testing.T) {
+	var buf bytes.Buffer
+	if isColorableTerminal(&buf) {
+		t.Fatal("expected a bytes.Buffer to never be colorable")
+	}
+}
+
+// TestIsColorableTerminalRespectsNoColor checks that NO_COLOR being set
+// overrides everything else, including a real terminal.
+func TestIsColorableTerminalRespectsNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	if isColorableTerminal(os.Stdout) {
+		t.Fatal("expected NO_COLOR to suppress colorization even for a TTY-backed file")
+	}
+}
+
+// TestTextHandlerUseColorsNoOpWithoutATerminal checks that UseColors on a
+// non-terminal writer (like bytes.Buffer, which TestIsColorableTerminalFalseForNonFile
+// already shows isn't colorable) produces plain, uncolored output.
+func TestTextHandlerUseColorsNoOpWithoutATerminal(t *testing.T) {
+	var buf bytes.Buffer
+	th := NewTextHandler(&buf)
+	th.UseColors = true
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", th)
+
+	l.D("hello")
+
+	if bytes.ContainsRune(buf.Bytes(), '\x1b') {
+		t.Fatalf("expected no ANSI escapes when writer isn't a terminal, got %q", buf.String())
+	}
+}
+
+// TestLevelColor checks the color assigned to each severity, including the
+// "no color" default for LevelOff.
+func TestLevelColor(t *testing.T) {
+	cases := []struct {
+		level Level
+		want  string
+	}{
+		{LevelDebug, ansiCyan},
+		{LevelWarn, ansiYellow},
+		{LevelError, ansiRed},
+		{LevelFatal, ansiMagenta},
+		{LevelOff, ""},
+	}
+	for _, c := range cases {
+		if got := levelColor(c.level); got != c.want {
+			t.Errorf("levelColor(%v) = %q, want %q", c.level, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+//go:build windows
+
+package logger
+
+import (
+	"os"
+	"syscall"
+
+	sequences "github.com/konsorten/go-windows-terminal-sequences"
+)
+
+// enableWindowsVirtualTerminal turns on ANSI escape sequence processing for
+// f's console, which older Windows terminals otherwise render escape codes
+// as garbage instead of interpreting them.
+func enableWindowsVirtualTerminal(f *os.File) {
+	_ = sequences.EnableVirtualTerminalProcessing(syscall.Handle(f.Fd()), true)
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewDistinct returns a logger derived from l that suppresses duplicate
+// records: a call that formats to the same text as one already logged
+// within window is dropped instead of being handed to the handlers. At most
+// maxEntries distinct formatted records are remembered at once; the least
+// recently seen one is evicted to make room for a new one.
+//
+// This is useful for noisy call sites, e.g. a retry loop that would
+// otherwise flood stdout with "disk almost full" on every iteration.
+func NewDistinct(l *logger, window time.Duration, maxEntries int) *logger {
+	derived := *l
+	derived.handlers = []Handler{newDistinctHandler(l.handlers, window, maxEntries)}
+	derived.level = cloneLevel(l.level)
+	return &derived
+}
+
+// distinctHandler wraps a set of handlers and drops records that duplicate
+// one already seen within window.
+type distinctHandler struct {
+	next   []Handler
+	window time.Duration
+	max    int
+
+	mu    sync.RWMutex
+	index map[string]*list.Element // key -> element in order, for O(1) lookup
+	order *list.List               // front = most recently seen
+}
+
+// distinctEntry is the value stored in distinctHandler.order.
+type distinctEntry struct {
+	key  string
+	seen time.Time
+}
+
+func newDistinctHandler(next []Handler, window time.Duration, maxEntries int) *distinctHandler {
+	if maxEntries <= 0 {
+		maxEntries = 1
+	}
+	return &distinctHandler{
+		next:   next,
+		window: window,
+		max:    maxEntries,
+		index:  make(map[string]*list.Element),
+		order:  list.New(),
+	}
+}
+
+// wantsCallerInfo implements callerAware by forwarding to the wrapped
+// handlers, so anyWantsCaller still sees through a NewDistinct-wrapped
+// logger instead of reporting false for it unconditionally.
+func (h *distinctHandler) wantsCallerInfo() bool {
+	return anyWantsCaller(h.next)
+}
+
+// Handle implements Handler.
+func (h *distinctHandler) Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error {
+	key := distinctKey(level, tag, args, fields)
+
+	h.mu.Lock()
+	if el, ok := h.index[key]; ok {
+		entry := el.Value.(*distinctEntry)
+		if ts.Sub(entry.seen) < h.window {
+			h.mu.Unlock()
+			return nil // duplicate within window: suppressed
+		}
+		entry.seen = ts
+		h.order.MoveToFront(el)
+	} else {
+		el := h.order.PushFront(&distinctEntry{key: key, seen: ts})
+		h.index[key] = el
+		if h.order.Len() > h.max {
+			oldest := h.order.Back()
+			h.order.Remove(oldest)
+			delete(h.index, oldest.Value.(*distinctEntry).key)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, n := range h.next {
+		if n == nil {
+			continue
+		}
+		_ = n.Handle(level, tag, ts, args, fields, caller)
+	}
+	return nil
+}
+
+// distinctKey builds the identity a record is deduplicated on: its level,
+// tag, formatted message and fields, but not its timestamp.
+func distinctKey(level Level, tag string, args []interface{}, fields []F) string {
+	var b strings.Builder
+	b.WriteString(level.String())
+	b.WriteByte('|')
+	b.WriteString(tag)
+	b.WriteByte('|')
+	fmt.Fprint(&b, args...)
+	for _, f := range fields {
+		fmt.Fprintf(&b, "|%s=%v", f.Key, f.Value)
+	}
+	return b.String()
+}
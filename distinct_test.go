@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNewDistinctSuppressesDuplicatesWithinWindow checks that a repeated
+// message is logged once and its repeats within window are dropped.
+func TestNewDistinctSuppressesDuplicatesWithinWindow(t *testing.T) {
+	ring := NewRingHandler(8)
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	l := NewDistinct(base, time.Hour, 8)
+
+	l.W("disk almost full")
+	l.W("disk almost full")
+	l.W("disk almost full")
+
+	entries := ring.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected duplicates to be suppressed, got %d entries", len(entries))
+	}
+}
+
+// TestNewDistinctHasIndependentLevel checks that SetLevel on a distinct
+// logger doesn't affect the logger it was derived from.
+func TestNewDistinctHasIndependentLevel(t *testing.T) {
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", NewRingHandler(1))
+	derived := NewDistinct(base, time.Hour, 8)
+
+	derived.SetLevel(LevelOff)
+
+	if base.Level() == LevelOff {
+		t.Fatalf("expected base logger's level to be unaffected by derived.SetLevel")
+	}
+}
+
+// TestNewDistinctForwardsCallerWant guards against distinctHandler silently
+// dropping caller info: wrapping a WithCaller TextHandler in NewDistinct
+// must not change whether the caller is captured and emitted.
+func TestNewDistinctForwardsCallerWant(t *testing.T) {
+	var buf bytes.Buffer
+	th := NewTextHandler(&buf)
+	th.WithCaller = true
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", th)
+	l := NewDistinct(base, time.Hour, 8)
+
+	l.W("disk almost full")
+
+	if !strings.Contains(buf.String(), "distinct_test.go:") {
+		t.Fatalf("expected output to contain a caller reference, got %q", buf.String())
+	}
+}
@@ -0,0 +1,35 @@
+package logger
+
+import "os"
+
+// Fatal logs v through the handler chain at fatal severity and then
+// terminates the process via os.Exit(1). Unlike CheckE, which panics and can
+// be recovered by BreakOnError, a Fatal call is never recoverable: logging is
+// unconditional here too, with no instance or global verbosity threshold able
+// to suppress it (CheckE's panic is likewise unconditional, but its logging
+// still goes through levelAllowed like D/W/E).
+//
+// This package otherwise names its severities with a single letter (D, W,
+// E), but that convention isn't available here: F already names the
+// structured field type.
+func (self *logger) Fatal(v ...interface{}) {
+	args, fields := splitFields(v)
+	caller := ""
+	if anyWantsCaller(self.handlers) {
+		caller = callerInfo(2)
+	}
+	dispatch(self.handlers, LevelFatal, self.tag, args, self.allFields(fields), caller)
+	os.Exit(1)
+}
+
+// Fatal logs v through the package default handlers at fatal severity and
+// then terminates the process via os.Exit(1). See (*logger).Fatal.
+func Fatal(v ...interface{}) {
+	args, fields := splitFields(v)
+	caller := ""
+	if anyWantsCaller(defaultHandlers) {
+		caller = callerInfo(2)
+	}
+	dispatch(defaultHandlers, LevelFatal, "", args, fields, caller)
+	os.Exit(1)
+}
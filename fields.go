@@ -0,0 +1,58 @@
+package logger
+
+// F is a single structured key/value pair that can be passed alongside the
+// message arguments to D/W/E/CheckW/CheckE/CheckMultiE, e.g.:
+//
+//	log.D("user login", logger.F{"uid", 42}, logger.F{"ip", ip})
+type F struct {
+	Key   string
+	Value interface{}
+}
+
+// Fields accumulates F values so callers can build up a field set once (e.g.
+// outside a hot loop) and reuse it across several log calls.
+type Fields []F
+
+// Add appends the given fields to the set.
+func (fs *Fields) Add(fields ...F) {
+	*fs = append(*fs, fields...)
+}
+
+// splitFields separates plain message arguments from F values embedded in v,
+// preserving the order of the non-field arguments.
+func splitFields(v []interface{}) ([]interface{}, []F) {
+	args := make([]interface{}, 0, len(v))
+	var fields []F
+	for _, a := range v {
+		if f, ok := a.(F); ok {
+			fields = append(fields, f)
+			continue
+		}
+		args = append(args, a)
+	}
+	return args, fields
+}
+
+// allFields combines the logger's sticky fields with per-call fields into a
+// freshly allocated slice, so dispatch never mutates self.fields' backing array.
+// With no sticky fields it returns extra as is.
+func (self *logger) allFields(extra []F) []F {
+	if len(self.fields) == 0 {
+		return extra
+	}
+	out := make([]F, len(self.fields)+len(extra))
+	copy(out, self.fields)
+	copy(out[len(self.fields):], extra)
+	return out
+}
+
+// With returns a derived logger that carries fields on every subsequent D/W/E
+// call in addition to whatever fields are passed at the call site. The
+// derived instance gets its own independent verbosity threshold, initialized
+// to self's current level.
+func (self *logger) With(fields ...F) *logger {
+	derived := *self
+	derived.fields = append(append([]F{}, self.fields...), fields...)
+	derived.level = cloneLevel(self.level)
+	return &derived
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestTextHandlerFormatsFieldsAsKeyValue checks that F values passed
+// alongside a message are rendered as "key=value" after it, in order.
+func TestTextHandlerFormatsFieldsAsKeyValue(t *testing.T) {
+	var buf bytes.Buffer
+	l := LoggerWithHandlers("db", 1, 1, 1, "", NewTextHandler(&buf))
+
+	l.W("connection lost", F{Key: "host", Value: "db1"}, F{Key: "retry", Value: 3})
+
+	want := "[WRN][db] connection lost host=db1 retry=3\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestJSONHandlerEmitsFieldsMap checks that F values are collected into the
+// JSON record's "fields" object, keyed by F.Key.
+func TestJSONHandlerEmitsFieldsMap(t *testing.T) {
+	var buf bytes.Buffer
+	l := LoggerWithHandlers("db", 1, 1, 1, "", NewJSONHandler(&buf))
+
+	l.W("connection lost", F{Key: "host", Value: "db1"}, F{Key: "retry", Value: float64(3)})
+
+	var rec struct {
+		Fields map[string]interface{} `json:"fields"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("expected valid JSON, got error %v on %q", err, buf.String())
+	}
+	if rec.Fields["host"] != "db1" || rec.Fields["retry"] != float64(3) {
+		t.Fatalf("expected fields map {host:db1, retry:3}, got %v", rec.Fields)
+	}
+}
@@ -0,0 +1,37 @@
+package logger
+
+import "testing"
+
+// TestWithAttachesStickyFields checks that fields passed to With are logged
+// alongside per-call fields on every subsequent call.
+func TestWithAttachesStickyFields(t *testing.T) {
+	ring := NewRingHandler(4)
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	l := base.With(F{Key: "uid", Value: 42})
+
+	l.D("login", F{Key: "ip", Value: "127.0.0.1"})
+
+	entries := ring.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	if len(fields) != 2 || fields[0].Key != "uid" || fields[1].Key != "ip" {
+		t.Fatalf("expected sticky field followed by call field, got %+v", fields)
+	}
+}
+
+// TestWithDoesNotMutateParent checks that a derived logger's fields don't
+// leak back into the logger it was derived from.
+func TestWithDoesNotMutateParent(t *testing.T) {
+	ring := NewRingHandler(4)
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	_ = base.With(F{Key: "uid", Value: 42})
+
+	base.D("plain")
+
+	entries := ring.Entries()
+	if len(entries[0].Fields) != 0 {
+		t.Fatalf("expected no fields on the base logger, got %+v", entries[0].Fields)
+	}
+}
@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level identifies the severity of a log record passed to a Handler, and
+// doubles as a verbosity threshold: LevelDebug < LevelWarn < LevelError <
+// LevelFatal < LevelOff, and a record is only logged once its level is >= the
+// effective threshold. See SetGlobalLevel and (*logger).SetLevel.
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelWarn
+	LevelError
+	LevelFatal
+	LevelOff
+)
+
+// String returns the short three-letter tag used by the built-in handlers.
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DBG"
+	case LevelWarn:
+		return "WRN"
+	case LevelError:
+		return "ERR"
+	case LevelFatal:
+		return "FTL"
+	case LevelOff:
+		return "OFF"
+	default:
+		return "???"
+	}
+}
+
+// Handler receives a log record once the per-instance and global level gates
+// have already let it through; implementations are responsible for formatting
+// and delivering the record (to stdout, a file, syslog, an in-memory buffer,
+// a remote sink, etc.) and should not re-check levels themselves.
+type Handler interface {
+	Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error
+}
+
+// callerAware is implemented by handlers whose WithCaller option determines
+// whether they want the original D/W/E call site resolved and forwarded.
+// dispatch uses it to skip the cost of runtime.Caller when nothing will use
+// the result.
+type callerAware interface {
+	wantsCallerInfo() bool
+}
+
+// anyWantsCaller reports whether at least one handler in handlers has opted
+// into caller info via its own WithCaller option.
+func anyWantsCaller(handlers []Handler) bool {
+	for _, h := range handlers {
+		if ca, ok := h.(callerAware); ok && ca.wantsCallerInfo() {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultHandlers is used by Logger(...) and the package-level D/W/E functions
+// when no handler is supplied, and preserves the package's historical
+// behavior of printing to stdout.
+var defaultHandlers = []Handler{NewTextHandler(os.Stdout)}
+
+// dispatch sends a record to every handler in turn, ignoring individual
+// handler errors: a failing sink (e.g. a full disk) must never stop other
+// sinks from receiving the record or prevent the caller from proceeding.
+func dispatch(handlers []Handler, level Level, tag string, args []interface{}, fields []F, caller string) {
+	args = redactArgs(args)
+	fields = redactFields(fields)
+	ts := time.Now()
+	for _, h := range handlers {
+		if h == nil {
+			continue
+		}
+		_ = h.Handle(level, tag, ts, args, fields, caller)
+	}
+}
+
+// TextHandler formats records as newline-delimited plain text and writes them
+// to an io.Writer, matching the historical "[DBG] ..." console output of this
+// package.
+type TextHandler struct {
+	// W is the destination the formatted lines are written to.
+	W io.Writer
+	// TimeFormat controls whether a timestamp is printed before the tag, and
+	// in which layout. Leave empty to omit the timestamp.
+	TimeFormat string
+	// UseColors wraps the level prefix and tag in ANSI SGR codes. It is only
+	// honored when W is a terminal and the NO_COLOR environment variable is
+	// unset; it has no effect otherwise.
+	UseColors bool
+	// WithCaller prepends the "file:line" of the original D/W/E call site,
+	// when the logger that owns this handler captured one.
+	WithCaller bool
+
+	mu       sync.Mutex
+	colorize bool
+	once     sync.Once
+}
+
+// NewTextHandler returns a Handler that writes human-readable lines to w.
+func NewTextHandler(w io.Writer) *TextHandler {
+	return &TextHandler{W: w}
+}
+
+// wantsCallerInfo implements callerAware.
+func (h *TextHandler) wantsCallerInfo() bool { return h.WithCaller }
+
+// Handle implements Handler.
+func (h *TextHandler) Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error {
+	if h.UseColors {
+		h.once.Do(func() { h.colorize = isColorableTerminal(h.W) })
+	}
+
+	var b strings.Builder
+	color := ""
+	if h.colorize {
+		color = levelColor(level)
+	}
+	if color != "" {
+		b.WriteString(color)
+	}
+	b.WriteString("[")
+	b.WriteString(level.String())
+	b.WriteString("]")
+	if color != "" {
+		b.WriteString(ansiReset)
+	}
+	if h.TimeFormat != "" {
+		b.WriteString("[")
+		b.WriteString(ts.Format(h.TimeFormat))
+		b.WriteString("]")
+	}
+	if tag != "" {
+		b.WriteString("[")
+		if h.colorize {
+			b.WriteString(ansiBold)
+		}
+		b.WriteString(tag)
+		if h.colorize {
+			b.WriteString(ansiReset)
+		}
+		b.WriteString("]")
+	}
+	if h.WithCaller && caller != "" {
+		b.WriteString(" ")
+		b.WriteString(caller)
+	}
+	b.WriteString(" ")
+	b.WriteString(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.W, b.String())
+	return err
+}
+
+// jsonRecord is the wire shape written by JSONHandler.
+type jsonRecord struct {
+	Time   time.Time              `json:"time"`
+	Level  string                 `json:"level"`
+	Tag    string                 `json:"tag,omitempty"`
+	Msg    string                 `json:"msg"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	Caller string                 `json:"caller,omitempty"`
+}
+
+// JSONHandler formats each record as a single-line JSON object of the shape
+// {time, level, tag, msg, fields} and writes it to an io.Writer. Setting
+// WithCaller adds a "caller" field, mirroring TextHandler's option.
+type JSONHandler struct {
+	W          io.Writer
+	WithCaller bool
+
+	mu sync.Mutex
+}
+
+// NewJSONHandler returns a Handler that writes one JSON object per record to w.
+func NewJSONHandler(w io.Writer) *JSONHandler {
+	return &JSONHandler{W: w}
+}
+
+// wantsCallerInfo implements callerAware.
+func (h *JSONHandler) wantsCallerInfo() bool { return h.WithCaller }
+
+// Handle implements Handler.
+func (h *JSONHandler) Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error {
+	rec := jsonRecord{
+		Time:  ts,
+		Level: level.String(),
+		Tag:   tag,
+		Msg:   fmt.Sprint(args...),
+	}
+	if h.WithCaller {
+		rec.Caller = caller
+	}
+	if len(fields) > 0 {
+		rec.Fields = make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			rec.Fields[f.Key] = f.Value
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	enc := json.NewEncoder(h.W)
+	return enc.Encode(rec)
+}
+
+// RingEntry is one record captured by a RingHandler.
+type RingEntry struct {
+	Level  Level
+	Tag    string
+	Time   time.Time
+	Args   []interface{}
+	Fields []F
+	Caller string
+}
+
+// RingHandler keeps the last N records in memory and discards older ones,
+// which makes it useful in tests (assert on what was logged without touching
+// stdout) and for crash dumps (keep a rolling window and flush it on panic).
+type RingHandler struct {
+	mu      sync.Mutex
+	entries []RingEntry
+	next    int
+	count   int
+}
+
+// NewRingHandler returns a Handler that retains at most size records.
+func NewRingHandler(size int) *RingHandler {
+	if size <= 0 {
+		size = 1
+	}
+	return &RingHandler{entries: make([]RingEntry, size)}
+}
+
+// Handle implements Handler.
+func (h *RingHandler) Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[h.next] = RingEntry{Level: level, Tag: tag, Time: ts, Args: args, Fields: fields, Caller: caller}
+	h.next = (h.next + 1) % len(h.entries)
+	if h.count < len(h.entries) {
+		h.count++
+	}
+	return nil
+}
+
+// Entries returns the retained records in the order they were logged.
+func (h *RingHandler) Entries() []RingEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]RingEntry, 0, h.count)
+	size := len(h.entries)
+	start := (h.next - h.count + size) % size
+	for i := 0; i < h.count; i++ {
+		out = append(out, h.entries[(start+i)%size])
+	}
+	return out
+}
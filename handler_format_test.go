@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestTextHandlerFormatsMessageLine checks the literal shape of a line
+// written by TextHandler: "[LVL][tag] message".
+func TestTextHandlerFormatsMessageLine(t *testing.T) {
+	var buf bytes.Buffer
+	l := LoggerWithHandlers("db", 1, 1, 1, "", NewTextHandler(&buf))
+
+	l.W("connection lost")
+
+	got := buf.String()
+	want := "[WRN][db] connection lost\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestJSONHandlerEmitsExpectedShape checks that JSONHandler writes the
+// {time, level, tag, msg, fields} wire shape the backlog asked for.
+func TestJSONHandlerEmitsExpectedShape(t *testing.T) {
+	var buf bytes.Buffer
+	l := LoggerWithHandlers("db", 1, 1, 1, "", NewJSONHandler(&buf))
+
+	l.E("connection lost")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("expected valid JSON, got error %v on %q", err, buf.String())
+	}
+	if _, ok := rec["time"]; !ok {
+		t.Errorf("expected a \"time\" key, got %v", rec)
+	}
+	if rec["level"] != "ERR" {
+		t.Errorf("expected level ERR, got %v", rec["level"])
+	}
+	if rec["tag"] != "db" {
+		t.Errorf("expected tag db, got %v", rec["tag"])
+	}
+	if rec["msg"] != "connection lost" {
+		t.Errorf("expected msg \"connection lost\", got %v", rec["msg"])
+	}
+	if _, ok := rec["fields"]; ok {
+		t.Errorf("expected no fields key when no fields were passed, got %v", rec["fields"])
+	}
+	if strings.Contains(buf.String(), "\"caller\"") {
+		t.Errorf("expected no caller key without WithCaller, got %q", buf.String())
+	}
+}
@@ -0,0 +1,29 @@
+package logger
+
+import "fmt"
+
+// NewIgnorable returns a logger derived from l whose Errorsf method demotes
+// errors identified by one of the given statement IDs to a warning, instead
+// of logging them at error severity.
+func NewIgnorable(l *logger, ignored ...string) *logger {
+	derived := *l
+	derived.level = cloneLevel(l.level)
+	derived.ignoreSet = make(map[string]struct{}, len(ignored))
+	for _, id := range ignored {
+		derived.ignoreSet[id] = struct{}{}
+	}
+	return &derived
+}
+
+// Errorsf formats a message identified by statementID and logs it.
+// If statementID was passed to NewIgnorable, the message is demoted to a
+// warning; otherwise it is logged as an error along with a hint on how to
+// silence it in the future.
+func (self *logger) Errorsf(statementID, format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	if _, ignored := self.ignoreSet[statementID]; ignored {
+		self.W(msg)
+		return
+	}
+	self.E(msg, fmt.Sprintf("(silence with ignoreErrors=[%q])", statementID))
+}
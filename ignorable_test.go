@@ -0,0 +1,26 @@
+package logger
+
+import "testing"
+
+// TestNewIgnorableDemotesListedStatements checks that Errorsf logs a
+// statement ID passed to NewIgnorable as a warning instead of an error, and
+// leaves other statement IDs at error severity.
+func TestNewIgnorableDemotesListedStatements(t *testing.T) {
+	ring := NewRingHandler(4)
+	base := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	l := NewIgnorable(base, "stmt-1")
+
+	l.Errorsf("stmt-1", "boom")
+	l.Errorsf("stmt-2", "boom")
+
+	entries := ring.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Level != LevelWarn {
+		t.Fatalf("expected stmt-1 to be demoted to LevelWarn, got %v", entries[0].Level)
+	}
+	if entries[1].Level != LevelError {
+		t.Fatalf("expected stmt-2 to stay at LevelError, got %v", entries[1].Level)
+	}
+}
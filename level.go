@@ -0,0 +1,97 @@
+package logger
+
+import "sync/atomic"
+
+// globalLevel is the process-wide verbosity threshold. Its zero value is
+// LevelDebug, matching this package's historical default of logging
+// everything.
+var globalLevel atomic.Int32
+
+// SetGlobalLevel adjusts the process-wide verbosity threshold at runtime,
+// e.g. in a SIGUSR1 handler to bump a long-running process to debug output.
+// It is safe to call concurrently with any logging call.
+func SetGlobalLevel(l Level) {
+	globalLevel.Store(int32(l))
+}
+
+// GlobalLevel returns the current process-wide verbosity threshold.
+func GlobalLevel() Level {
+	return Level(globalLevel.Load())
+}
+
+// Deprecated: DEBUG, WARN, and ERROR are preserved only so that existing
+// `== 1` comparisons against these names keep compiling for one release
+// cycle. Use SetGlobalLevel/GlobalLevel instead.
+const (
+	DEBUG Level = 1
+	WARN  Level = 1
+	ERROR Level = 1
+)
+
+// defaultInstanceLevel derives a starting per-instance threshold from the
+// legacy d/w/e enable flags, for Level() to report before SetLevel is ever
+// called. It's a best-effort monotonic approximation only: it can't
+// represent a non-monotonic combination like d=1,w=0,e=1, which is why
+// levelAllowed gates on the flags directly until SetLevel opts a logger into
+// threshold-based gating.
+func defaultInstanceLevel(d, w, e int) Level {
+	switch {
+	case d == 1:
+		return LevelDebug
+	case w == 1:
+		return LevelWarn
+	case e == 1:
+		return LevelError
+	default:
+		return LevelOff
+	}
+}
+
+// levelState holds a logger instance's verbosity threshold plus whether
+// SetLevel has ever been called on it. Before the first SetLevel call,
+// levelAllowed gates purely on the legacy d/w/e flags (preserving
+// non-monotonic combinations like "debug+error on, warn off"); SetLevel
+// commits the logger to the new threshold-based gating, which can enable a
+// severity whose flag was 0 at construction.
+type levelState struct {
+	threshold  atomic.Int32
+	overridden atomic.Bool
+}
+
+// SetLevel adjusts this logger instance's verbosity threshold at runtime,
+// and switches it from the legacy per-flag gating to threshold-based gating.
+// It is safe to call concurrently with D/W/E/CheckW/CheckE/CheckMultiE from
+// any goroutine.
+func (self *logger) SetLevel(l Level) {
+	self.level.threshold.Store(int32(l))
+	self.level.overridden.Store(true)
+}
+
+// Level returns this logger instance's current verbosity threshold: the
+// value set by the most recent SetLevel call, or a best-effort approximation
+// of the construction-time d/w/e flags if SetLevel has never been called.
+func (self *logger) Level() Level {
+	return Level(self.level.threshold.Load())
+}
+
+// cloneLevel returns a new levelState holding l's current threshold and
+// overridden bit, so derived loggers (With, NewDistinct, NewIgnorable, ...)
+// get independent verbosity state instead of sharing their parent's.
+func cloneLevel(l *levelState) *levelState {
+	c := &levelState{}
+	c.threshold.Store(l.threshold.Load())
+	c.overridden.Store(l.overridden.Load())
+	return c
+}
+
+// levelAllowed reports whether a record at the given level should be
+// dispatched. Until SetLevel has been called on this instance, it gates on
+// the legacy flag for level's severity (preserving non-monotonic d/w/e
+// combinations); afterwards it gates on the instance's threshold instead. In
+// both cases the global verbosity threshold must also allow the record.
+func (self *logger) levelAllowed(flag int, level Level) bool {
+	if self.level.overridden.Load() {
+		return self.Level() <= level && GlobalLevel() <= level
+	}
+	return flag == 1 && GlobalLevel() <= level
+}
@@ -0,0 +1,68 @@
+package logger
+
+import "testing"
+
+// TestSetLevelCanEnableDisabledSeverity guards against levelAllowed still
+// gating on the legacy construction-time d/w/e flags after SetLevel has been
+// called: SetLevel must be able to turn on
+// a severity that was off at construction, not just adjust one that was
+// already on.
+func TestSetLevelCanEnableDisabledSeverity(t *testing.T) {
+	ring := NewRingHandler(8)
+	l := LoggerWithHandlers("x", 0, 0, 1, "", ring) // debug disabled at construction
+
+	l.D("should not appear yet")
+	if len(ring.Entries()) != 0 {
+		t.Fatalf("expected no entries before SetLevel, got %d", len(ring.Entries()))
+	}
+
+	l.SetLevel(LevelDebug)
+	l.D("now visible")
+
+	entries := ring.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry after SetLevel(LevelDebug), got %d", len(entries))
+	}
+	if entries[0].Level != LevelDebug {
+		t.Fatalf("expected LevelDebug entry, got %v", entries[0].Level)
+	}
+}
+
+// TestSetLevelCanRaiseThreshold exercises the existing behavior: raising the
+// threshold above a severity suppresses it again.
+func TestSetLevelCanRaiseThreshold(t *testing.T) {
+	ring := NewRingHandler(8)
+	l := LoggerWithHandlers("x", 1, 1, 1, "", ring)
+
+	l.SetLevel(LevelError)
+	l.D("suppressed")
+	l.W("suppressed")
+	l.E("kept")
+
+	entries := ring.Entries()
+	if len(entries) != 1 || entries[0].Level != LevelError {
+		t.Fatalf("expected exactly one LevelError entry, got %+v", entries)
+	}
+}
+
+// TestNonMonotonicFlagsHonoredUntilSetLevel guards against a regression
+// where collapsing d/w/e into a single ordered threshold broke a
+// non-monotonic combination like "debug+error on, warn off": before
+// SetLevel is ever called, each severity must still be gated by its own
+// flag, not by a threshold that can't represent "on, off, on".
+func TestNonMonotonicFlagsHonoredUntilSetLevel(t *testing.T) {
+	ring := NewRingHandler(8)
+	l := LoggerWithHandlers("x", 1, 0, 1, "", ring) // debug+error on, warn off
+
+	l.D("debug")
+	l.W("warn, should be suppressed")
+	l.E("error")
+
+	entries := ring.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries (debug, error), got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Level != LevelDebug || entries[1].Level != LevelError {
+		t.Fatalf("expected debug then error, got %+v", entries)
+	}
+}
@@ -1,146 +1,205 @@
 // Package logger provides a simple leveled logging facility with tag support.
-// It allows enabling/disabling logging globally for DEBUG, WARN, and ERROR levels,
-// and also provides per-logger instance control over these levels.
+// Verbosity is controlled by a runtime-adjustable Level threshold, both
+// globally (see SetGlobalLevel) and per logger instance (see (*logger).SetLevel).
+// Log records are delivered through one or more pluggable Handlers, so callers
+// can route output to stdout, files, syslog, in-memory buffers, or remote sinks.
 // The package includes helper functions for checking errors and potentially panicking.
 package logger
 
-import (
-	"fmt"
-	"time"
-)
-
-// DEBUG controls the global enablement of debug level logging.
-// Set to 1 to enable, 0 to disable. Affects log printouts only.
-// This setting is applied *after* per-logger tag level flags.
-const DEBUG = 1
-
-// WARN controls the global enablement of warning level logging.
-// Set to 1 to enable, 0 to disable. Affects log printouts only.
-// This setting is applied *after* per-logger tag level flags.
-const WARN = 1
-
-// ERROR controls the global enablement of error level logging.
-// Set to 1 to enable, 0 to disable. Affects log printouts only.
-// Panic behavior in CheckE/CheckMultiE functions is *not* affected by this flag.
-// This setting is applied *after* per-logger tag level flags.
-const ERROR = 1
+import "os"
 
 // logger represents a logging instance with a specific tag and level controls.
 type logger struct {
 	tag        string // Tag prepended to log messages for this logger instance.
 	d, w, e    int    // Level enable flags (1 for enabled, 0 for disabled) for Debug, Warn, Error.
 	timeFormat string // Time format string for logging timestamps.
+	handlers   []Handler
+	fields     []F // Sticky fields attached by With(), logged on every call.
+	level      *levelState
+	ignoreSet  map[string]struct{} // Statement IDs demoted by Errorsf; set by NewIgnorable.
 }
 
-// Logger creates and returns a new logger instance.
+// Logger creates and returns a new logger instance that delivers records to
+// the package default handlers (a TextHandler on stdout). Use
+// LoggerWithHandlers to route a logger's output elsewhere.
 // Parameters:
 //   - tag: A string identifier prepended to messages logged by this instance (e.g., "[Database]").
 //   - d: Set to 1 to enable Debug level logging for this instance, 0 to disable.
 //   - w: Set to 1 to enable Warn level logging for this instance, 0 to disable.
 //   - e: Set to 1 to enable Error level logging for this instance, 0 to disable.
-//   - timeFormat: Time format string for logging timestamps. If empty, no timestamp is logged.
+//   - timeFormat: Time format string for timestamps. Pass "" or omit it for no timestamp.
 //
-// Note: Global DEBUG, WARN, ERROR flags must also be enabled for messages to be printed.
+// Note: the global verbosity threshold (see SetGlobalLevel) is applied in
+// addition to these per-instance flags.
 func Logger(tag string, d int, w int, e int, timeFormat ...string) *logger {
 	tf := ""
 	if len(timeFormat) > 0 {
 		tf = timeFormat[0]
 	}
-	return &logger{tag: tag, d: d, w: w, e: e, timeFormat: tf}
+	return LoggerWithHandlers(tag, d, w, e, tf)
 }
 
-// formatMessage formats the message with the logger's tag and timestamp if timeFormat is set.
-func (self *logger) formatMessage() string {
-	if self.timeFormat != "" {
-		return fmt.Sprintf("[%s][%s]", time.Now().Format(self.timeFormat), self.tag)
+// LoggerWithHandlers is Logger, but delivers records to the given handlers
+// instead of the package default.
+// Parameters:
+//   - tag, d, w, e, timeFormat: see Logger.
+//   - handlers: Where records are delivered. If none are given, the logger
+//     falls back to a TextHandler on stdout (honoring timeFormat), matching
+//     this package's historical behavior.
+func LoggerWithHandlers(tag string, d int, w int, e int, timeFormat string, handlers ...Handler) *logger {
+	h := handlers
+	if len(h) == 0 {
+		if timeFormat != "" {
+			th := NewTextHandler(os.Stdout)
+			th.TimeFormat = timeFormat
+			h = []Handler{th}
+		} else {
+			h = defaultHandlers
+		}
 	}
-	return fmt.Sprintf("[%s]", self.tag)
+	level := &levelState{}
+	level.threshold.Store(int32(defaultInstanceLevel(d, w, e)))
+	return &logger{tag: tag, d: d, w: w, e: e, timeFormat: timeFormat, handlers: h, level: level}
 }
 
 // D logs a debug message if debug logging is enabled for this logger instance
-// and globally. The logger's tag is automatically prepended.
+// and globally. The logger's tag is automatically prepended. Any argument of
+// type F is treated as a structured field rather than message text, and is
+// logged alongside the fields accumulated via With().
 func (self *logger) D(v ...interface{}) {
-	if self.d == 1 {
-		D(append([]interface{}{self.formatMessage()}, v...)...)
+	if self.levelAllowed(self.d, LevelDebug) {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(self.handlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(self.handlers, LevelDebug, self.tag, args, self.allFields(fields), caller)
 	}
 }
 
-// D logs a global debug message if global debug logging (DEBUG constant) is enabled.
-// Arguments are printed space-separated, followed by a newline.
+// D logs a global debug message if the global verbosity threshold allows debug output.
+// Arguments are printed space-separated, followed by a newline. Any argument
+// of type F is logged as a structured field instead of message text.
 func D(v ...interface{}) {
-	if DEBUG == 1 {
-		fmt.Println("[DBG]", v)
+	if GlobalLevel() <= LevelDebug {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(defaultHandlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(defaultHandlers, LevelDebug, "", args, fields, caller)
 	}
 }
 
 // W logs a warning message if warning logging is enabled for this logger instance
-// and globally. The logger's tag is automatically prepended.
+// and globally. The logger's tag is automatically prepended. Any argument of
+// type F is treated as a structured field rather than message text, and is
+// logged alongside the fields accumulated via With().
 func (self *logger) W(v ...interface{}) {
-	if self.w == 1 {
-		W(append([]interface{}{self.formatMessage()}, v...)...)
+	if self.levelAllowed(self.w, LevelWarn) {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(self.handlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(self.handlers, LevelWarn, self.tag, args, self.allFields(fields), caller)
 	}
 }
 
-// W logs a global warning message if global warning logging (WARN constant) is enabled.
-// Arguments are printed space-separated, followed by a newline.
+// W logs a global warning message if the global verbosity threshold allows warn output.
+// Arguments are printed space-separated, followed by a newline. Any argument
+// of type F is logged as a structured field instead of message text.
 func W(v ...interface{}) {
-	if WARN == 1 {
-		fmt.Println("[WRN]", v)
+	if GlobalLevel() <= LevelWarn {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(defaultHandlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(defaultHandlers, LevelWarn, "", args, fields, caller)
 	}
 }
 
 // E logs an error message if error logging is enabled for this logger instance
-// and globally. The logger's tag is automatically prepended.
+// and globally. The logger's tag is automatically prepended. Any argument of
+// type F is treated as a structured field rather than message text, and is
+// logged alongside the fields accumulated via With().
 func (self *logger) E(v ...interface{}) {
-	if self.e == 1 {
-		E(append([]interface{}{self.formatMessage()}, v...)...)
+	if self.levelAllowed(self.e, LevelError) {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(self.handlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(self.handlers, LevelError, self.tag, args, self.allFields(fields), caller)
 	}
 }
 
-// E logs a global error message if global error logging (ERROR constant) is enabled.
-// Arguments are printed space-separated, followed by a newline.
+// E logs a global error message if the global verbosity threshold allows error output.
+// Arguments are printed space-separated, followed by a newline. Any argument
+// of type F is logged as a structured field instead of message text.
 func E(v ...interface{}) {
-	if ERROR == 1 {
-		fmt.Println("[ERR]", v)
+	if GlobalLevel() <= LevelError {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(defaultHandlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(defaultHandlers, LevelError, "", args, fields, caller)
 	}
 }
 
 // CheckW checks if the provided error `err` is non-nil. If it is, and if
 // warning logging is enabled for this logger instance and globally, it logs
-// the error along with the provided arguments `v` (prepended by the logger's tag).
+// the error along with the provided arguments `v` (prepended by the logger's
+// tag). Any argument of type F is logged as a structured field.
 // Returns true if `err` is non-nil, false otherwise.
 func (self *logger) CheckW(err error, v ...interface{}) bool {
-	if self.w == 1 {
-		return CheckW(err, append([]interface{}{fmt.Sprintf("[%s]", self.tag)}, v...)...)
+	if err != nil && self.levelAllowed(self.w, LevelWarn) {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(self.handlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(self.handlers, LevelWarn, self.tag, append(args, err), self.allFields(fields), caller)
 	}
-	// Still return whether an error occurred, even if logging is disabled.
 	return err != nil
 }
 
 // CheckW checks if the provided error `err` is non-nil. If it is, and if
-// global warning logging (WARN constant) is enabled, it logs the error along
+// the global verbosity threshold allows warn output, it logs the error along
 // with the provided arguments `v`.
 // Returns true if `err` is non-nil, false otherwise.
 func CheckW(err error, v ...interface{}) bool {
-	if err != nil {
-		W(append(v, err)...) // Append err to the message arguments
+	if err != nil && GlobalLevel() <= LevelWarn {
+		args, fields := splitFields(v)
+		caller := ""
+		if anyWantsCaller(defaultHandlers) {
+			caller = callerInfo(2)
+		}
+		dispatch(defaultHandlers, LevelWarn, "", append(args, err), fields, caller)
 	}
-
 	return err != nil
 }
 
 // CheckE checks if the provided error `err` is non-nil.
 // If `err` is non-nil:
 //  1. If error logging is enabled for this logger instance and globally, it logs
-//     the error along with the provided arguments `v` (prepended by the logger's tag).
+//     the error along with the provided arguments `v` (prepended by the logger's
+//     tag). Any argument of type F is logged as a structured field.
 //  2. It then panics with the error `err`.
 //
-// If error logging is disabled for this instance but `err` is non-nil, it still panics.
+// If error logging is disabled for this instance but `err` is non-nil, it still
+// panics. Fields attached via `v` or With() never alter the panic value.
 func (self *logger) CheckE(err error, v ...interface{}) {
 	if err != nil { // Check for error first to ensure panic happens regardless of log level
-		if self.e == 1 && ERROR == 1 { // Check both instance and global flags for logging
-			// Use the global E function to handle the actual print logic
-			E(append([]interface{}{fmt.Sprintf("[%s]", self.tag)}, append(v, err)...)...)
+		if self.levelAllowed(self.e, LevelError) { // Check both instance and global level thresholds
+			args, fields := splitFields(v)
+			caller := ""
+			if anyWantsCaller(self.handlers) {
+				caller = callerInfo(2)
+			}
+			dispatch(self.handlers, LevelError, self.tag, append(args, err), self.allFields(fields), caller)
 		}
 		panic(err) // Panic regardless of whether it was logged
 	}
@@ -148,12 +207,19 @@ func (self *logger) CheckE(err error, v ...interface{}) {
 
 // CheckE checks if the provided error `err` is non-nil.
 // If `err` is non-nil:
-//  1. If global error logging (ERROR constant) is enabled, it logs the error
+//  1. If the global verbosity threshold allows error output, it logs the error
 //     along with the provided arguments `v`.
 //  2. It then panics with the error `err`.
 func CheckE(err error, v ...interface{}) {
 	if err != nil {
-		E(append(v, err)...) // Append err to the message arguments
+		if GlobalLevel() <= LevelError {
+			args, fields := splitFields(v)
+			caller := ""
+			if anyWantsCaller(defaultHandlers) {
+				caller = callerInfo(2)
+			}
+			dispatch(defaultHandlers, LevelError, "", append(args, err), fields, caller)
+		}
 		panic(err)
 	}
 }
@@ -161,21 +227,25 @@ func CheckE(err error, v ...interface{}) {
 // CheckMultiE checks if the provided slice of errors `err` contains any non-nil errors.
 // If it finds non-nil errors:
 //  1. If error logging is enabled for this logger instance and globally, it logs
-//     each non-nil error along with the provided arguments `v` (prepended by the logger's tag).
+//     each non-nil error along with the provided arguments `v` (prepended by the
+//     logger's tag). Any argument of type F is logged as a structured field.
 //  2. It then panics with the *first* non-nil error encountered in the slice.
 //
-// If error logging is disabled for this instance but non-nil errors exist, it still panics
-// with the first non-nil error.
+// If error logging is disabled for this instance but non-nil errors exist, it still
+// panics with the first non-nil error. Fields never alter the panic value.
 func (self *logger) CheckMultiE(errs []error, v ...interface{}) {
 	firstErr := findFirstError(errs)
 	if firstErr != nil { // Check for error first
-		if self.e == 1 && ERROR == 1 { // Check both instance and global flags for logging
-			// Log each non-nil error individually
-			prefix := fmt.Sprintf("[%s]", self.tag)
+		if self.levelAllowed(self.e, LevelError) { // Check both instance and global level thresholds
+			args, fields := splitFields(v)
+			allFields := self.allFields(fields)
+			caller := ""
+			if anyWantsCaller(self.handlers) {
+				caller = callerInfo(2)
+			}
 			for _, err := range errs {
 				if err != nil {
-					// Use the global E function to handle the actual print logic
-					E(append([]interface{}{prefix}, append(v, err)...)...)
+					dispatch(self.handlers, LevelError, self.tag, append(args, err), allFields, caller)
 				}
 			}
 		}
@@ -185,17 +255,22 @@ func (self *logger) CheckMultiE(errs []error, v ...interface{}) {
 
 // CheckMultiE checks if the provided slice of errors `errs` contains any non-nil errors.
 // If it finds non-nil errors:
-//  1. If global error logging (ERROR constant) is enabled, it logs each non-nil
+//  1. If the global verbosity threshold allows error output, it logs each non-nil
 //     error along with the provided arguments `v`.
 //  2. It then panics with the *first* non-nil error encountered in the slice.
 func CheckMultiE(errs []error, v ...interface{}) {
 	firstErr := findFirstError(errs)
 	if firstErr != nil {
-		if ERROR == 1 {
+		if GlobalLevel() <= LevelError {
+			args, fields := splitFields(v)
+			caller := ""
+			if anyWantsCaller(defaultHandlers) {
+				caller = callerInfo(2)
+			}
 			// Log each non-nil error individually
 			for _, err := range errs {
 				if err != nil {
-					E(append(v, err)...) // Append err to the message arguments
+					dispatch(defaultHandlers, LevelError, "", append(args, err), fields, caller)
 				}
 			}
 		}
@@ -222,7 +297,7 @@ func findFirstError(errs []error) error {
 // Example:
 //
 //	func myOperation() {
-//	    log := log.Logger("MyOp", 1, 1, 1)
+//	    log := log.Logger("MyOp", 1, 1, 1, "")
 //	    defer log.BreakOnError() // or defer log.BreakOnError()
 //
 //	    _, err := potentiallyFailingCall()
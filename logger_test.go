@@ -0,0 +1,27 @@
+package logger
+
+import "testing"
+
+// TestLoggerOptionalTimeFormat guards against a regression where timeFormat
+// became a required positional argument: Logger("tag", 1, 1, 1), valid and
+// idiomatic before handlers were introduced, must keep compiling and working.
+func TestLoggerOptionalTimeFormat(t *testing.T) {
+	l := Logger("tag", 1, 1, 1)
+	if l.timeFormat != "" {
+		t.Fatalf("expected empty timeFormat by default, got %q", l.timeFormat)
+	}
+}
+
+// TestLoggerWithHandlersRoutesRecords checks that handlers passed to
+// LoggerWithHandlers receive dispatched records instead of the package
+// default.
+func TestLoggerWithHandlersRoutesRecords(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	l.D("hello")
+
+	entries := ring.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+}
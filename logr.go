@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// Logr returns a logr.Logger backed by self, so this package can drop into
+// klog/controller-runtime style code without callers writing their own
+// adapter. The returned logger reuses self's handlers, sticky fields, and
+// verbosity threshold: V(0) (logr's default) maps to W, any higher verbosity
+// maps to D, and Error maps to E.
+func (self *logger) Logr() logr.Logger {
+	return logr.New(&logrSink{l: self})
+}
+
+// logrSink implements logr.LogSink on top of a *logger.
+type logrSink struct {
+	l *logger
+}
+
+var _ logr.LogSink = (*logrSink)(nil)
+
+// Init implements logr.LogSink. There's nothing to record: this package
+// derives caller info from its own call stack rather than logr's RuntimeInfo.
+func (s *logrSink) Init(info logr.RuntimeInfo) {}
+
+// Enabled implements logr.LogSink.
+func (s *logrSink) Enabled(level int) bool {
+	if level > 0 {
+		return s.l.levelAllowed(s.l.d, LevelDebug)
+	}
+	return s.l.levelAllowed(s.l.w, LevelWarn)
+}
+
+// Info implements logr.LogSink, mapping V(0) to W and any higher verbosity to D.
+func (s *logrSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	args := append([]interface{}{msg}, kvToArgs(keysAndValues)...)
+	if level > 0 {
+		s.l.D(args...)
+	} else {
+		s.l.W(args...)
+	}
+}
+
+// Error implements logr.LogSink.
+func (s *logrSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	args := append([]interface{}{msg, err}, kvToArgs(keysAndValues)...)
+	s.l.E(args...)
+}
+
+// WithValues implements logr.LogSink by attaching the key/value pairs as
+// sticky fields via With.
+func (s *logrSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &logrSink{l: s.l.With(kvToFields(keysAndValues)...)}
+}
+
+// WithName implements logr.LogSink by appending name to the logger's tag,
+// dot-separated, matching logr's own convention for nested component names.
+func (s *logrSink) WithName(name string) logr.LogSink {
+	derived := *s.l
+	if derived.tag != "" {
+		derived.tag = derived.tag + "." + name
+	} else {
+		derived.tag = name
+	}
+	derived.level = cloneLevel(s.l.level)
+	return &logrSink{l: &derived}
+}
+
+// kvToFields converts logr's flat key/value pairs into F values, dropping an
+// unpaired trailing key.
+func kvToFields(keysAndValues []interface{}) []F {
+	fields := make([]F, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, F{Key: key, Value: keysAndValues[i+1]})
+	}
+	return fields
+}
+
+// kvToArgs converts logr's flat key/value pairs into F arguments suitable for
+// D/W/E.
+func kvToArgs(keysAndValues []interface{}) []interface{} {
+	fields := kvToFields(keysAndValues)
+	args := make([]interface{}, len(fields))
+	for i, f := range fields {
+		args[i] = f
+	}
+	return args
+}
@@ -0,0 +1,60 @@
+package logger
+
+import "testing"
+
+// TestLogrInfoMapsVerbosityToDebugOrWarn checks logr's V(0)/V(n>0) split
+// against this package's W/D severities.
+func TestLogrInfoMapsVerbosityToDebugOrWarn(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	sink := l.Logr()
+
+	sink.V(0).Info("warn-level")
+	sink.V(1).Info("debug-level")
+
+	entries := ring.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Level != LevelWarn {
+		t.Fatalf("expected V(0) to map to LevelWarn, got %v", entries[0].Level)
+	}
+	if entries[1].Level != LevelDebug {
+		t.Fatalf("expected V(1) to map to LevelDebug, got %v", entries[1].Level)
+	}
+}
+
+// TestLogrErrorMapsToError checks that Error() logs at LevelError.
+func TestLogrErrorMapsToError(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	sink := l.Logr()
+
+	sink.Error(errBoom, "failed")
+
+	entries := ring.Entries()
+	if len(entries) != 1 || entries[0].Level != LevelError {
+		t.Fatalf("expected 1 LevelError entry, got %+v", entries)
+	}
+}
+
+// TestLogrWithValuesAttachesFields checks that WithValues threads its
+// key/value pairs through as sticky fields.
+func TestLogrWithValuesAttachesFields(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+	sink := l.Logr().WithValues("uid", 42)
+
+	sink.Info("login")
+
+	fields := ring.Entries()[0].Fields
+	if len(fields) != 1 || fields[0].Key != "uid" || fields[0].Value != 42 {
+		t.Fatalf("expected uid=42 field, got %+v", fields)
+	}
+}
+
+type boomError struct{}
+
+func (boomError) Error() string { return "boom" }
+
+var errBoom = boomError{}
@@ -0,0 +1,63 @@
+package logger
+
+import "strings"
+
+// Redactor is implemented by argument types that know how to mask their own
+// sensitive value before it reaches a Handler. D/W/E and the Check* variants
+// substitute Redacted() for any argument or field value that implements it,
+// so a handler (including the JSON handler) never sees the original value.
+type Redactor interface {
+	Redacted() interface{}
+}
+
+// Redact returns one '*' for every byte of s, preserving only its length in
+// bytes. It is a convenience for implementing Redactor.
+func Redact(s string) string {
+	return strings.Repeat("*", len(s))
+}
+
+// Password is a string that redacts itself when logged, e.g.:
+//
+//	log.D("login", logger.Password(pw))
+type Password string
+
+// Redacted implements Redactor.
+func (p Password) Redacted() interface{} {
+	return Redact(string(p))
+}
+
+// redactArgs replaces any argument implementing Redactor with the result of
+// its Redacted() method, leaving everything else untouched. It only
+// allocates a new slice if a Redactor is actually found.
+func redactArgs(args []interface{}) []interface{} {
+	var out []interface{}
+	for i, a := range args {
+		if r, ok := a.(Redactor); ok {
+			if out == nil {
+				out = append([]interface{}{}, args...)
+			}
+			out[i] = r.Redacted()
+		}
+	}
+	if out == nil {
+		return args
+	}
+	return out
+}
+
+// redactFields is redactArgs for structured field values.
+func redactFields(fields []F) []F {
+	var out []F
+	for i, f := range fields {
+		if r, ok := f.Value.(Redactor); ok {
+			if out == nil {
+				out = append([]F{}, fields...)
+			}
+			out[i].Value = r.Redacted()
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
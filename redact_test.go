@@ -0,0 +1,31 @@
+package logger
+
+import "testing"
+
+// TestPasswordRedactsInArgsAndFields checks that a Redactor value is masked
+// before it reaches a Handler, both as a plain argument and as a field value.
+func TestPasswordRedactsInArgsAndFields(t *testing.T) {
+	ring := NewRingHandler(4)
+	l := LoggerWithHandlers("tag", 1, 1, 1, "", ring)
+
+	l.D("login", Password("hunter2"), F{Key: "pw", Value: Password("hunter2")})
+
+	entries := ring.Entries()
+	got := entries[0].Args[1]
+	if got != "*******" {
+		t.Fatalf("expected redacted arg, got %v", got)
+	}
+	if entries[0].Fields[0].Value != "*******" {
+		t.Fatalf("expected redacted field, got %v", entries[0].Fields[0].Value)
+	}
+}
+
+// TestRedactArgsLeavesNonRedactorsUntouched checks that redactArgs doesn't
+// allocate or alter a slice with no Redactor values.
+func TestRedactArgsLeavesNonRedactorsUntouched(t *testing.T) {
+	args := []interface{}{"plain", 42}
+	out := redactArgs(args)
+	if &out[0] != &args[0] {
+		t.Fatalf("expected redactArgs to return the same backing array when nothing redacts")
+	}
+}
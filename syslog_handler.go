@@ -0,0 +1,54 @@
+//go:build !windows
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+	"time"
+)
+
+// SyslogHandler forwards records to the local syslog daemon.
+// It is only available on platforms with log/syslog support. Setting
+// WithCaller prepends the caller info, mirroring TextHandler's option.
+type SyslogHandler struct {
+	w          *syslog.Writer
+	WithCaller bool
+}
+
+// NewSyslogHandler opens a connection to the local syslog daemon, tagging
+// every message with tag.
+func NewSyslogHandler(tag string) (*SyslogHandler, error) {
+	w, err := syslog.New(syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogHandler{w: w}, nil
+}
+
+// wantsCallerInfo implements callerAware.
+func (h *SyslogHandler) wantsCallerInfo() bool { return h.WithCaller }
+
+// Handle implements Handler.
+func (h *SyslogHandler) Handle(level Level, tag string, ts time.Time, args []interface{}, fields []F, caller string) error {
+	msg := fmt.Sprint(args...)
+	for _, f := range fields {
+		msg += fmt.Sprintf(" %s=%v", f.Key, f.Value)
+	}
+	if h.WithCaller && caller != "" {
+		msg = caller + " " + msg
+	}
+	if tag != "" {
+		msg = "[" + tag + "] " + msg
+	}
+	switch level {
+	case LevelDebug:
+		return h.w.Debug(msg)
+	case LevelWarn:
+		return h.w.Warning(msg)
+	case LevelError:
+		return h.w.Err(msg)
+	default:
+		return h.w.Info(msg)
+	}
+}